@@ -0,0 +1,60 @@
+//go:build (amd64 && !purego) || (arm64 && !purego)
+// +build amd64,!purego arm64,!purego
+
+package sumhash
+
+import "golang.org/x/sys/cpu"
+
+// asmCompressor wraps a LookupTable and dispatches Compress to a
+// platform-specific SIMD kernel when the CPU supports it, falling back to
+// the portable LookupTable.Compress otherwise. Compress is the hot loop of
+// the whole hash (every call walks the full N*M/8 table), so amortizing it
+// over 4 output rows at a time with vector loads/adds is worth the
+// platform-specific code.
+type asmCompressor struct {
+	LookupTable
+	enabled bool
+}
+
+// NewAsmCompressor returns a Compressor equivalent to A.LookupTable() that
+// uses an AVX2 (amd64) or NEON (arm64) accelerated Compress when the
+// running CPU supports it, and transparently falls back to the portable
+// Go implementation otherwise.
+func NewAsmCompressor(A Matrix) Compressor {
+	return &asmCompressor{LookupTable: A.LookupTable(), enabled: hasSIMD()}
+}
+
+func hasSIMD() bool {
+	return cpu.X86.HasAVX2 || cpu.ARM64.HasASIMD
+}
+
+func (c *asmCompressor) Compress(dst []uint64, msg []byte) {
+	_ = msg[c.InputLen()-1]
+	_ = dst[c.OutputLen()-1]
+
+	if !c.enabled {
+		c.LookupTable.Compress(dst, msg)
+		return
+	}
+
+	n := len(c.LookupTable)
+	i := 0
+	for ; i+4 <= n; i += 4 {
+		compressRows4(
+			dst[i:i+4],
+			c.LookupTable[i],
+			c.LookupTable[i+1],
+			c.LookupTable[i+2],
+			c.LookupTable[i+3],
+			msg,
+		)
+	}
+	// Tail: fewer than 4 rows remain, finish with the portable path.
+	for ; i < n; i++ {
+		var x uint64
+		for j := range c.LookupTable[i] {
+			x += c.LookupTable[i][j][msg[j]]
+		}
+		dst[i] = x
+	}
+}
@@ -0,0 +1,11 @@
+//go:build (amd64 && !purego) || (arm64 && !purego)
+// +build amd64,!purego arm64,!purego
+
+package sumhash
+
+// compressRows4 sums table[j][msg[j]] over j for each of the four given
+// per-row lookup tables and writes the four results into dst. It is
+// implemented in compress_amd64.s (AVX2) and compress_arm64.s (NEON).
+//
+//go:noescape
+func compressRows4(dst []uint64, row0, row1, row2, row3 [][256]uint64, msg []byte)
@@ -0,0 +1,44 @@
+//go:build (amd64 && !purego) || (arm64 && !purego)
+// +build amd64,!purego arm64,!purego
+
+package sumhash
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// TestAsmCompressorMatchesLookupTable checks that NewAsmCompressor agrees
+// with the portable LookupTable.Compress it wraps, across a handful of
+// N/compressionFactor combinations including ones that aren't a multiple
+// of the 4-row asm stride.
+func TestAsmCompressorMatchesLookupTable(t *testing.T) {
+	for _, params := range []struct{ N, compressionFactor int }{
+		{1, 2},
+		{3, 2},
+		{4, 2},
+		{5, 2},
+		{8, 2},
+		{14, 3},
+	} {
+		A := RandomMatrix(rand.Reader, params.N, params.compressionFactor)
+		want := A.LookupTable()
+		got := NewAsmCompressor(A)
+
+		msg := make([]byte, want.InputLen())
+		if _, err := rand.Read(msg); err != nil {
+			t.Fatal(err)
+		}
+
+		wantDst := make([]uint64, want.OutputLen())
+		gotDst := make([]uint64, got.OutputLen())
+		want.Compress(wantDst, msg)
+		got.Compress(gotDst, msg)
+
+		for i := range wantDst {
+			if wantDst[i] != gotDst[i] {
+				t.Fatalf("N=%d,c=%d: row %d: asm=%d portable=%d", params.N, params.compressionFactor, i, gotDst[i], wantDst[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package sumhash
+
+import (
+	"crypto/rand"
+	"strconv"
+	"testing"
+)
+
+func benchmarkCompress(b *testing.B, N, compressionFactor int, newCompressor func(Matrix) Compressor) {
+	A := RandomMatrix(rand.Reader, N, compressionFactor)
+	c := newCompressor(A)
+	msg := make([]byte, c.InputLen())
+	if _, err := rand.Read(msg); err != nil {
+		b.Fatal(err)
+	}
+	dst := make([]uint64, c.OutputLen())
+
+	b.SetBytes(int64(c.InputLen()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Compress(dst, msg)
+	}
+}
+
+func BenchmarkCompressMatrix(b *testing.B) {
+	for _, params := range []struct{ N, compressionFactor int }{
+		{8, 2},
+		{14, 3},
+	} {
+		b.Run(paramName(params.N, params.compressionFactor), func(b *testing.B) {
+			benchmarkCompress(b, params.N, params.compressionFactor, func(A Matrix) Compressor { return A })
+		})
+	}
+}
+
+func BenchmarkCompressLookupTable(b *testing.B) {
+	for _, params := range []struct{ N, compressionFactor int }{
+		{8, 2},
+		{14, 3},
+	} {
+		b.Run(paramName(params.N, params.compressionFactor), func(b *testing.B) {
+			benchmarkCompress(b, params.N, params.compressionFactor, func(A Matrix) Compressor { return A.LookupTable() })
+		})
+	}
+}
+
+func BenchmarkCompressAsm(b *testing.B) {
+	for _, params := range []struct{ N, compressionFactor int }{
+		{8, 2},
+		{14, 3},
+	} {
+		b.Run(paramName(params.N, params.compressionFactor), func(b *testing.B) {
+			benchmarkCompress(b, params.N, params.compressionFactor, NewAsmCompressor)
+		})
+	}
+}
+
+func paramName(N, compressionFactor int) string {
+	return "N=" + strconv.Itoa(N) + ",c=" + strconv.Itoa(compressionFactor)
+}
@@ -0,0 +1,11 @@
+//go:build (!amd64 && !arm64) || purego
+// +build !amd64,!arm64 purego
+
+package sumhash
+
+// NewAsmCompressor returns a Compressor equivalent to A.LookupTable(). On
+// this platform (or when built with the purego tag) no SIMD kernel is
+// available, so it is simply the portable LookupTable.
+func NewAsmCompressor(A Matrix) Compressor {
+	return A.LookupTable()
+}
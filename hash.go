@@ -2,6 +2,7 @@ package sumhash
 
 import (
 	"encoding/binary"
+	"errors"
 	"hash"
 	"io"
 )
@@ -207,6 +208,69 @@ func (d *digest) checkSum() []byte {
 	return digest
 }
 
+const magic = "sumhash\x01"
+
+// MarshalBinary implements encoding.BinaryMarshaler. It allows a caller to
+// checkpoint a long-running hash (sumhash is considerably slower than the
+// SHA-2 family) and resume it later with UnmarshalBinary, provided the same
+// Compressor is used to reconstruct the digest via New.
+func (d *digest) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, len(magic)+8+8+len(d.h)*8+len(d.x)+8+8)
+	b = append(b, magic...)
+	b = appendUint64(b, uint64(d.size))
+	b = appendUint64(b, uint64(d.blockSize))
+	for _, h := range d.h {
+		b = appendUint64(b, h)
+	}
+	b = append(b, d.x...)
+	b = appendUint64(b, uint64(d.nx))
+	b = appendUint64(b, d.len)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The digest must
+// already be constructed with New using a Compressor whose OutputLen and
+// InputLen match the ones the state was marshaled with, or UnmarshalBinary
+// returns an error.
+func (d *digest) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic) || string(b[:len(magic)]) != magic {
+		return errors.New("sumhash: invalid hash state identifier")
+	}
+	b = b[len(magic):]
+	if len(b) < 16 {
+		return errors.New("sumhash: invalid hash state size")
+	}
+	size, b := consumeUint64(b)
+	blockSize, b := consumeUint64(b)
+	if int(size) != d.size || int(blockSize) != d.blockSize {
+		return errors.New("sumhash: hash state is for a different compressor")
+	}
+	want := len(d.h)*8 + d.blockSize + 16
+	if len(b) != want {
+		return errors.New("sumhash: invalid hash state size")
+	}
+	for i := range d.h {
+		d.h[i], b = consumeUint64(b)
+	}
+	copy(d.x, b[:d.blockSize])
+	b = b[d.blockSize:]
+	var nx uint64
+	nx, b = consumeUint64(b)
+	d.nx = int(nx)
+	d.len, b = consumeUint64(b)
+	return nil
+}
+
+func appendUint64(b []byte, x uint64) []byte {
+	var a [8]byte
+	binary.LittleEndian.PutUint64(a[:], x)
+	return append(b, a[:]...)
+}
+
+func consumeUint64(b []byte) (uint64, []byte) {
+	return binary.LittleEndian.Uint64(b[0:8]), b[8:]
+}
+
 func blocks(d *digest, data []byte) {
 	msg := make([]byte, d.c.InputLen())
 	for i := 0; i <= len(data)-d.blockSize; i += d.blockSize {
@@ -0,0 +1,62 @@
+package sumhash
+
+import (
+	"bytes"
+	"encoding"
+	"testing"
+)
+
+// This mirrors the kind of marshal test crypto/sha1 ships: split a message
+// across a MarshalBinary/UnmarshalBinary boundary and check the resumed
+// digest agrees with hashing the message straight through.
+func TestDigestMarshalRoundTrip(t *testing.T) {
+	c := testCompressor()
+	full := []byte("a fairly long message used to test checkpointing digest state across a marshal and unmarshal boundary, long enough to span several blocks of input")
+
+	want := New(c)
+	want.Write(full)
+	wantSum := want.Sum(nil)
+
+	split := len(full) / 2
+	h := New(c)
+	h.Write(full[:split])
+
+	state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := New(c)
+	if err := resumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		t.Fatal(err)
+	}
+	resumed.Write(full[split:])
+	got := resumed.Sum(nil)
+	if !bytes.Equal(got, wantSum) {
+		t.Fatalf("resumed digest = %x, want %x", got, wantSum)
+	}
+
+	// MarshalBinary must not disturb the original digest either.
+	h.Write(full[split:])
+	gotOriginal := h.Sum(nil)
+	if !bytes.Equal(gotOriginal, wantSum) {
+		t.Fatalf("original digest after MarshalBinary = %x, want %x", gotOriginal, wantSum)
+	}
+}
+
+func TestDigestUnmarshalRejectsMismatchedCompressor(t *testing.T) {
+	c1 := testCompressor()
+	c2 := RandomMatrix(&counterReader{}, 3, 2).LookupTable()
+
+	h1 := New(c1)
+	h1.Write([]byte("abc"))
+	state, err := h1.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h2 := New(c2)
+	if err := h2.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err == nil {
+		t.Fatal("expected an error unmarshaling state produced by a different compressor")
+	}
+}
@@ -0,0 +1,109 @@
+package sumhash
+
+import (
+	"errors"
+	"hash"
+)
+
+// mac implements an HMAC-like construction over a sumhash Compressor. It
+// follows the same inner/outer padding scheme as crypto/hmac, except the
+// pad length is the compressor's block size (InputLen - OutputLen*8)
+// rather than a fixed constant, since that ratio is unusual for sumhash
+// and varies per Compressor.
+type mac struct {
+	c            Compressor
+	blockSize    int
+	opad, ipad   []byte
+	outer, inner hash.Hash
+}
+
+// NewMAC returns an HMAC-like hash.Hash keyed with key, built on top of c.
+// Keys longer than c's block size (InputLen()-OutputLen()*8) are shrunk
+// with New(c) first, exactly as crypto/hmac does for oversized keys.
+func NewMAC(c Compressor, key []byte) hash.Hash {
+	blockSize := c.InputLen() - c.OutputLen()*8
+	m := &mac{
+		c:         c,
+		blockSize: blockSize,
+		inner:     New(c),
+		outer:     New(c),
+	}
+
+	if len(key) > blockSize {
+		h := New(c)
+		h.Write(key)
+		key = h.Sum(nil)
+	}
+
+	m.ipad = make([]byte, blockSize)
+	m.opad = make([]byte, blockSize)
+	copy(m.ipad, key)
+	copy(m.opad, key)
+	for i := range m.ipad {
+		m.ipad[i] ^= 0x36
+	}
+	for i := range m.opad {
+		m.opad[i] ^= 0x5c
+	}
+	m.inner.Write(m.ipad)
+	return m
+}
+
+func (m *mac) Write(p []byte) (int, error) { return m.inner.Write(p) }
+
+func (m *mac) Size() int      { return m.inner.Size() }
+func (m *mac) BlockSize() int { return m.blockSize }
+
+func (m *mac) Reset() {
+	m.inner.Reset()
+	m.inner.Write(m.ipad)
+}
+
+func (m *mac) Sum(in []byte) []byte {
+	origLen := len(in)
+	in = m.inner.Sum(in)
+
+	m.outer.Reset()
+	m.outer.Write(m.opad)
+	m.outer.Write(in[origLen:])
+	return m.outer.Sum(in[:origLen])
+}
+
+// Extract implements the HKDF-extract step (RFC 5869 section 2.2) using
+// NewMAC as the underlying PRF: it derives a pseudorandom key from salt
+// and input keying material ikm. If salt is empty, a zero-filled salt of
+// the MAC's output length is used, per the RFC.
+func Extract(c Compressor, salt, ikm []byte) []byte {
+	if len(salt) == 0 {
+		salt = make([]byte, c.OutputLen()*8)
+	}
+	h := NewMAC(c, salt)
+	h.Write(ikm)
+	return h.Sum(nil)
+}
+
+// Expand implements the HKDF-expand step (RFC 5869 section 2.3): it
+// derives L bytes of output keying material from a pseudorandom key prk
+// (as produced by Extract) and optional context info. It returns an error
+// instead of the OKM if L requires more than 255 iterations of the
+// underlying MAC, per the RFC's bound on expand output length.
+func Expand(c Compressor, prk, info []byte, L int) ([]byte, error) {
+	h := NewMAC(c, prk)
+	hashLen := h.Size()
+	n := (L + hashLen - 1) / hashLen
+	if n > 255 {
+		return nil, errors.New("sumhash: Expand length too large")
+	}
+
+	okm := make([]byte, 0, n*hashLen)
+	var t []byte
+	for i := 1; i <= n; i++ {
+		h.Reset()
+		h.Write(t)
+		h.Write(info)
+		h.Write([]byte{byte(i)})
+		t = h.Sum(nil)
+		okm = append(okm, t...)
+	}
+	return okm[:L], nil
+}
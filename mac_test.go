@@ -0,0 +1,156 @@
+package sumhash
+
+import (
+	"bytes"
+	"testing"
+)
+
+// counterReader is a deterministic, non-cryptographic io.Reader used only
+// to build small fixed test matrices: RandomMatrix(counterReader{}, ...)
+// gives the same Matrix on every run without depending on math/rand's
+// output format across Go versions.
+type counterReader struct{ n byte }
+
+func (r *counterReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.n
+		r.n++
+	}
+	return len(p), nil
+}
+
+func testCompressor() Compressor {
+	// compressionFactor must be > 1 so blockSize (InputLen-OutputLen*8) is
+	// positive: InputLen = compressionFactor*N*8, OutputLen*8 = N*8.
+	return RandomMatrix(&counterReader{}, 2, 2).LookupTable()
+}
+
+// referenceMAC is a direct, unoptimized transcription of the HMAC
+// construction (RFC 2104) against c, independent of the mac type in
+// mac.go, used as a known-answer oracle for NewMAC.
+func referenceMAC(c Compressor, key, msg []byte) []byte {
+	blockSize := c.InputLen() - c.OutputLen()*8
+	if len(key) > blockSize {
+		h := New(c)
+		h.Write(key)
+		key = h.Sum(nil)
+	}
+	ipad := make([]byte, blockSize)
+	opad := make([]byte, blockSize)
+	copy(ipad, key)
+	copy(opad, key)
+	for i := range ipad {
+		ipad[i] ^= 0x36
+	}
+	for i := range opad {
+		opad[i] ^= 0x5c
+	}
+
+	inner := New(c)
+	inner.Write(ipad)
+	inner.Write(msg)
+	innerSum := inner.Sum(nil)
+
+	outer := New(c)
+	outer.Write(opad)
+	outer.Write(innerSum)
+	return outer.Sum(nil)
+}
+
+func TestMACMatchesReferenceConstruction(t *testing.T) {
+	c := testCompressor()
+	blockSize := c.InputLen() - c.OutputLen()*8
+
+	keys := [][]byte{
+		[]byte("short key"),
+		bytes.Repeat([]byte{0xab}, blockSize+17), // oversized: exercises the New(c) key-shrink branch
+	}
+	msgs := [][]byte{
+		[]byte(""),
+		[]byte("the quick brown fox"),
+	}
+
+	for _, key := range keys {
+		for _, msg := range msgs {
+			want := referenceMAC(c, key, msg)
+
+			h := NewMAC(c, key)
+			h.Write(msg)
+			got := h.Sum(nil)
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("NewMAC(key=%d bytes, msg=%q) = %x, want %x", len(key), msg, got, want)
+			}
+		}
+	}
+}
+
+func TestMACResetMatchesFreshInstance(t *testing.T) {
+	c := testCompressor()
+	key := []byte("a mac key")
+
+	h := NewMAC(c, key)
+	h.Write([]byte("first message"))
+	h.Sum(nil)
+	h.Reset()
+	h.Write([]byte("second message"))
+	got := h.Sum(nil)
+
+	want := referenceMAC(c, key, []byte("second message"))
+	if !bytes.Equal(got, want) {
+		t.Errorf("after Reset: got %x, want %x", got, want)
+	}
+}
+
+func TestExtractExpandDeterministic(t *testing.T) {
+	c := testCompressor()
+	salt := []byte("salt")
+	ikm := []byte("input keying material")
+	info := []byte("context info")
+	const L = 37 // not a multiple of the MAC's output size
+
+	prk1 := Extract(c, salt, ikm)
+	prk2 := Extract(c, salt, ikm)
+	if !bytes.Equal(prk1, prk2) {
+		t.Fatalf("Extract is not deterministic: %x != %x", prk1, prk2)
+	}
+
+	okm1, err := Expand(c, prk1, info, L)
+	if err != nil {
+		t.Fatal(err)
+	}
+	okm2, err := Expand(c, prk1, info, L)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(okm1) != L {
+		t.Fatalf("Expand returned %d bytes, want %d", len(okm1), L)
+	}
+	if !bytes.Equal(okm1, okm2) {
+		t.Fatalf("Expand is not deterministic: %x != %x", okm1, okm2)
+	}
+
+	okmOtherInfo, err := Expand(c, prk1, []byte("different info"), L)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(okm1, okmOtherInfo) {
+		t.Fatal("Expand produced the same OKM for different info")
+	}
+
+	prkEmptySalt := Extract(c, nil, ikm)
+	if bytes.Equal(prkEmptySalt, prk1) {
+		t.Fatal("Extract produced the same PRK for an empty salt as for an explicit one")
+	}
+}
+
+func TestExpandRejectsLengthTooLarge(t *testing.T) {
+	c := testCompressor()
+	prk := Extract(c, []byte("salt"), []byte("ikm"))
+	hashLen := NewMAC(c, prk).Size()
+
+	_, err := Expand(c, prk, nil, 255*hashLen+1)
+	if err == nil {
+		t.Fatal("Expand did not return an error for an excessive length")
+	}
+}
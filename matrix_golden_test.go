@@ -0,0 +1,76 @@
+package sumhash
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+type goldenVector struct {
+	InputHex  string `json:"input_hex"`
+	DigestHex string `json:"digest_hex"`
+}
+
+type goldenFile struct {
+	SeedHex           string         `json:"seed_hex"`
+	N                 int            `json:"N"`
+	CompressionFactor int            `json:"compressionFactor"`
+	Vectors           []goldenVector `json:"vectors"`
+}
+
+// TestGoldenVectors checks that the shipped testdata/matrix_N14_c3.bin,
+// produced by RandomMatrixFromSeed, round-trips through Matrix's
+// MarshalBinary/UnmarshalBinary and reproduces the (input, digest) pairs
+// in testdata/vectors_N14_c3.json. Any other implementation of sumhash
+// that reproduces these digests from the same matrix is interoperable
+// with this one.
+func TestGoldenVectors(t *testing.T) {
+	matrixBytes, err := os.ReadFile("testdata/matrix_N14_c3.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var A Matrix
+	if err := A.UnmarshalBinary(matrixBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	vectorBytes, err := os.ReadFile("testdata/vectors_N14_c3.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gf goldenFile
+	if err := json.Unmarshal(vectorBytes, &gf); err != nil {
+		t.Fatal(err)
+	}
+
+	seed, err := hex.DecodeString(gf.SeedHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RandomMatrixFromSeed(seed, gf.N, gf.CompressionFactor)
+	gotBytes, _ := A.MarshalBinary()
+	wantBytes, _ := want.MarshalBinary()
+	if string(gotBytes) != string(wantBytes) {
+		t.Fatalf("testdata/matrix_N14_c3.bin does not match RandomMatrixFromSeed(seed, %d, %d)", gf.N, gf.CompressionFactor)
+	}
+
+	table := A.LookupTable()
+	for i, v := range gf.Vectors {
+		input, err := hex.DecodeString(v.InputHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantDigest, err := hex.DecodeString(v.DigestHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h := New(table)
+		h.Write(input)
+		gotDigest := h.Sum(nil)
+		if string(gotDigest) != string(wantDigest) {
+			t.Errorf("vector %d: digest mismatch\n got:  %x\n want: %x", i, gotDigest, wantDigest)
+		}
+	}
+}
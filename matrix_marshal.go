@@ -0,0 +1,63 @@
+package sumhash
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// RandomMatrixFromSeed deterministically derives a Matrix from seed using
+// SHAKE256 as a CSPRNG, instead of an arbitrary io.Reader as RandomMatrix
+// takes. Given the same seed, N and compressionFactor, it always produces
+// the same Matrix, which is what makes it suitable as a cross-language,
+// cross-implementation interoperability anchor (see testdata).
+func RandomMatrixFromSeed(seed []byte, N, compressionFactor int) Matrix {
+	shake := sha3.NewShake256()
+	shake.Write(seed)
+	return RandomMatrix(shake, N, compressionFactor)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It serializes N, M
+// and every entry of A as little-endian uint64s, with no versioning magic
+// since the matrix dimensions themselves are self-describing.
+func (A Matrix) MarshalBinary() ([]byte, error) {
+	N := len(A)
+	M := 0
+	if N > 0 {
+		M = len(A[0])
+	}
+	b := make([]byte, 0, 16+8*N*M)
+	b = appendUint64(b, uint64(N))
+	b = appendUint64(b, uint64(M))
+	for _, row := range A {
+		for _, v := range row {
+			b = appendUint64(b, v)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the format
+// produced by MarshalBinary.
+func (A *Matrix) UnmarshalBinary(b []byte) error {
+	if len(b) < 16 {
+		return errors.New("sumhash: invalid matrix state size")
+	}
+	n, b := consumeUint64(b)
+	m, b := consumeUint64(b)
+	N, M := int(n), int(m)
+	if uint64(len(b)) != uint64(N)*uint64(M)*8 {
+		return errors.New("sumhash: invalid matrix state size")
+	}
+
+	rows := make([][]uint64, N)
+	for i := range rows {
+		row := make([]uint64, M)
+		for j := range row {
+			row[j], b = consumeUint64(b)
+		}
+		rows[i] = row
+	}
+	*A = rows
+	return nil
+}
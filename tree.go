@@ -0,0 +1,176 @@
+package sumhash
+
+import (
+	"hash"
+	"runtime"
+	"sync"
+)
+
+// Tree is a streaming Merkle-tree hash built on top of a Compressor. Input
+// written to it is split into leafSize-byte chunks, each hashed with
+// New(c), and the resulting leaf digests are combined pairwise up to a
+// single root digest, duplicating the last node of any odd layer
+// (BLAKE3-style) so every layer has an even number of nodes to combine.
+//
+// Leaf hashing is parallelized across runtime.NumCPU() goroutines, which
+// matters because sumhash's per-byte cost is much higher than SHA-2's.
+type Tree struct {
+	c        Compressor
+	leafSize int
+
+	buf    []byte
+	leaves [][]byte
+
+	built  bool
+	layers [][][]byte // layers[0] are leaf digests, layers[len(layers)-1] is [root]
+}
+
+// NewTree returns a hash.Hash that computes a binary Merkle tree hash of
+// its input over c, with each leaf covering up to leafSize bytes. The
+// concrete type returned is *Tree; callers that need TreeProof should
+// type-assert back to it.
+func NewTree(c Compressor, leafSize int) hash.Hash {
+	if leafSize <= 0 {
+		panic("sumhash: leafSize must be positive")
+	}
+	t := &Tree{c: c, leafSize: leafSize}
+	t.Reset()
+	return t
+}
+
+func (t *Tree) Write(p []byte) (n int, err error) {
+	n = len(p)
+	t.built = false
+	t.buf = append(t.buf, p...)
+	for len(t.buf) >= t.leafSize {
+		leaf := make([]byte, t.leafSize)
+		copy(leaf, t.buf[:t.leafSize])
+		t.leaves = append(t.leaves, leaf)
+		t.buf = t.buf[t.leafSize:]
+	}
+	return n, nil
+}
+
+func (t *Tree) Reset() {
+	t.buf = t.buf[:0]
+	t.leaves = nil
+	t.layers = nil
+	t.built = false
+}
+
+func (t *Tree) Size() int      { return t.c.OutputLen() * 8 }
+func (t *Tree) BlockSize() int { return t.leafSize }
+
+func (t *Tree) Sum(in []byte) []byte {
+	t.build()
+	root := t.layers[len(t.layers)-1][0]
+	return append(in, root...)
+}
+
+// TreeProof returns the authentication path for the leaf at index: the
+// sibling digest at every layer from the leaf up to (but not including)
+// the root, in bottom-up order. It finalizes the tree as a side effect, as
+// Sum does.
+func (t *Tree) TreeProof(index int) [][]byte {
+	t.build()
+	// t.layers[0] is the true leaf count: build() may have appended a
+	// trailing partial leaf from t.buf that isn't reflected in t.leaves.
+	if index < 0 || index >= len(t.layers[0]) {
+		panic("sumhash: leaf index out of range")
+	}
+
+	var path [][]byte
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		sibling := index ^ 1
+		if sibling >= len(layer) {
+			sibling = index // odd layer: last node was duplicated as its own sibling
+		}
+		path = append(path, layer[sibling])
+		index /= 2
+	}
+	return path
+}
+
+func (t *Tree) build() {
+	if t.built {
+		return
+	}
+
+	leaves := t.leaves
+	if len(t.buf) > 0 || len(leaves) == 0 {
+		leaves = append(append([][]byte{}, leaves...), append([]byte{}, t.buf...))
+	}
+
+	digests := make([][]byte, len(leaves))
+	hashLeaves(t.c, leaves, digests)
+
+	layers := [][][]byte{digests}
+	for len(layers[len(layers)-1]) > 1 {
+		layers = append(layers, combineLayer(t.c, layers[len(layers)-1]))
+	}
+
+	t.layers = layers
+	t.built = true
+}
+
+// hashLeaves hashes each leaf with New(c), spreading the work across
+// runtime.NumCPU() goroutines.
+func hashLeaves(c Compressor, leaves [][]byte, digests [][]byte) {
+	workers := runtime.NumCPU()
+	if workers > len(leaves) {
+		workers = len(leaves)
+	}
+	if workers <= 1 {
+		for i, leaf := range leaves {
+			digests[i] = sumLeaf(c, leaf)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	var next int64
+	var mu sync.Mutex
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				i := int(next)
+				if i >= len(leaves) {
+					mu.Unlock()
+					return
+				}
+				next++
+				mu.Unlock()
+				digests[i] = sumLeaf(c, leaves[i])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func sumLeaf(c Compressor, leaf []byte) []byte {
+	h := New(c)
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+// combineLayer pairwise-combines a layer of digests into its parent layer,
+// duplicating the last node if the layer has an odd length.
+func combineLayer(c Compressor, layer [][]byte) [][]byte {
+	n := len(layer)
+	parent := make([][]byte, 0, (n+1)/2)
+	for i := 0; i < n; i += 2 {
+		left := layer[i]
+		right := left
+		if i+1 < n {
+			right = layer[i+1]
+		}
+		h := New(c)
+		h.Write(left)
+		h.Write(right)
+		parent = append(parent, h.Sum(nil))
+	}
+	return parent
+}
@@ -0,0 +1,146 @@
+package sumhash
+
+import "encoding/binary"
+
+// XOF is an extendable-output function: unlike hash.Hash it can be read
+// from an arbitrary number of times to produce output of any length,
+// following the absorb/squeeze split used by SHA-3's SHAKE and BLAKE3.
+type XOF interface {
+	Write(p []byte) (n int, err error)
+	Read(p []byte) (n int, err error)
+	Reset()
+}
+
+// xofPad marks the end of absorbed input when finalizing for squeezing.
+// It is distinct from the 0x80 padding byte digest.checkSum uses for
+// fixed-output Sum, so a XOF and a hash.Hash built on the same Compressor
+// and fed the same bytes never collide on output.
+const xofPad = 0x1f
+
+// xof implements XOF on top of a Compressor. Input is absorbed the same
+// way digest does, optionally prefixed with a domain string at
+// construction for domain separation between independent XOF uses of the
+// same Compressor. On the first Read, it finalizes the absorbed state and
+// then squeezes output by repeatedly re-compressing the chained state
+// with an incrementing counter: h_{i+1} = C(h_i || counter || pad).
+type xof struct {
+	c         Compressor
+	size      int // c.OutputLen() * 8, in bytes
+	blockSize int // c.InputLen() - size
+	domain    []byte
+
+	h  []uint64
+	x  []byte
+	nx int
+
+	squeezing bool
+	ctr       uint64
+	out       []byte
+}
+
+// NewXOF returns an extendable-output function built on c. If domain is
+// non-empty it is absorbed first, so that different domains yield
+// independent output streams even for identical subsequent input.
+func NewXOF(c Compressor, domain []byte) XOF {
+	x := &xof{
+		c:         c,
+		size:      c.OutputLen() * 8,
+		blockSize: c.InputLen() - c.OutputLen()*8,
+		domain:    append([]byte(nil), domain...),
+	}
+	x.h = make([]uint64, c.OutputLen())
+	x.x = make([]byte, x.blockSize)
+	x.Reset()
+	return x
+}
+
+// Reset restores the XOF to the state right after construction, including
+// re-absorbing domain so that Reset followed by the same Write calls
+// reproduces the output of a freshly constructed NewXOF(c, domain).
+func (x *xof) Reset() {
+	for i := range x.h {
+		x.h[i] = 0
+	}
+	x.nx = 0
+	x.squeezing = false
+	x.ctr = 0
+	x.out = nil
+	if len(x.domain) > 0 {
+		x.Write(x.domain)
+	}
+}
+
+func (x *xof) Write(p []byte) (n int, err error) {
+	if x.squeezing {
+		panic("sumhash: Write after Read on XOF")
+	}
+	n = len(p)
+	if x.nx > 0 {
+		c := copy(x.x[x.nx:], p)
+		x.nx += c
+		if x.nx == x.blockSize {
+			x.absorbBlock(x.x)
+			x.nx = 0
+		}
+		p = p[c:]
+	}
+	for len(p) >= x.blockSize {
+		x.absorbBlock(p[:x.blockSize])
+		p = p[x.blockSize:]
+	}
+	if len(p) > 0 {
+		x.nx = copy(x.x, p)
+	}
+	return n, nil
+}
+
+func (x *xof) absorbBlock(block []byte) {
+	msg := make([]byte, x.c.InputLen())
+	for j := range x.h {
+		binary.LittleEndian.PutUint64(msg[8*j:8*j+8], x.h[j])
+	}
+	copy(msg[x.size:x.size+x.blockSize], block)
+	x.c.Compress(x.h, msg)
+}
+
+func (x *xof) finalize() {
+	tmp := make([]byte, x.blockSize)
+	tmp[0] = xofPad
+	x.Write(tmp[:x.blockSize-x.nx])
+	if x.nx != 0 {
+		panic("sumhash: xof.nx != 0 after padding")
+	}
+	x.squeezing = true
+}
+
+func (x *xof) squeezeBlock() {
+	msg := make([]byte, x.c.InputLen())
+	for j := range x.h {
+		binary.LittleEndian.PutUint64(msg[8*j:8*j+8], x.h[j])
+	}
+	binary.LittleEndian.PutUint64(msg[x.size:x.size+8], x.ctr)
+	x.c.Compress(x.h, msg)
+	x.ctr++
+
+	out := make([]byte, x.size)
+	for j := range x.h {
+		binary.LittleEndian.PutUint64(out[8*j:8*j+8], x.h[j])
+	}
+	x.out = out
+}
+
+func (x *xof) Read(p []byte) (n int, err error) {
+	if !x.squeezing {
+		x.finalize()
+	}
+	for len(p) > 0 {
+		if len(x.out) == 0 {
+			x.squeezeBlock()
+		}
+		c := copy(p, x.out)
+		p = p[c:]
+		x.out = x.out[c:]
+		n += c
+	}
+	return n, nil
+}
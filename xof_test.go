@@ -0,0 +1,108 @@
+package sumhash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func sumXOF(x XOF, n int) []byte {
+	out := make([]byte, n)
+	x.Read(out)
+	return out
+}
+
+func TestXOFDeterministic(t *testing.T) {
+	c := testCompressor()
+	msg := []byte("some input to absorb")
+
+	x1 := NewXOF(c, []byte("domain"))
+	x1.Write(msg)
+	out1 := sumXOF(x1, 100)
+
+	x2 := NewXOF(c, []byte("domain"))
+	x2.Write(msg)
+	out2 := sumXOF(x2, 100)
+
+	if !bytes.Equal(out1, out2) {
+		t.Fatalf("NewXOF is not deterministic: %x != %x", out1, out2)
+	}
+}
+
+func TestXOFDomainSeparation(t *testing.T) {
+	c := testCompressor()
+	msg := []byte("same input for both domains")
+
+	x1 := NewXOF(c, []byte("domain-a"))
+	x1.Write(msg)
+	out1 := sumXOF(x1, 64)
+
+	x2 := NewXOF(c, []byte("domain-b"))
+	x2.Write(msg)
+	out2 := sumXOF(x2, 64)
+
+	if bytes.Equal(out1, out2) {
+		t.Fatal("different domains produced identical XOF output")
+	}
+
+	x3 := NewXOF(c, nil)
+	x3.Write(msg)
+	out3 := sumXOF(x3, 64)
+	if bytes.Equal(out1, out3) || bytes.Equal(out2, out3) {
+		t.Fatal("empty domain collided with a non-empty domain")
+	}
+}
+
+func TestXOFStreamedReadMatchesBulkRead(t *testing.T) {
+	c := testCompressor()
+	msg := []byte("streamed vs bulk read must agree")
+
+	bulk := NewXOF(c, []byte("d"))
+	bulk.Write(msg)
+	bulkOut := sumXOF(bulk, 97) // not a multiple of the squeeze block size
+
+	streamed := NewXOF(c, []byte("d"))
+	streamed.Write(msg)
+	var streamedOut []byte
+	for _, n := range []int{1, 3, 7, 20, 30, 36} {
+		streamedOut = append(streamedOut, sumXOF(streamed, n)...)
+	}
+
+	if !bytes.Equal(bulkOut, streamedOut) {
+		t.Fatalf("streamed read (%x) != bulk read (%x)", streamedOut, bulkOut)
+	}
+}
+
+func TestXOFResetReproducesFreshInstance(t *testing.T) {
+	c := testCompressor()
+	domain := []byte("reset-domain")
+	msg := []byte("message after reset")
+
+	x := NewXOF(c, domain)
+	x.Write([]byte("some earlier message that gets discarded"))
+	sumXOF(x, 16)
+	x.Reset()
+	x.Write(msg)
+	gotAfterReset := sumXOF(x, 48)
+
+	fresh := NewXOF(c, domain)
+	fresh.Write(msg)
+	wantFresh := sumXOF(fresh, 48)
+
+	if !bytes.Equal(gotAfterReset, wantFresh) {
+		t.Fatalf("Reset did not reproduce a fresh NewXOF(c, domain): got %x, want %x", gotAfterReset, wantFresh)
+	}
+}
+
+func TestXOFWriteAfterReadPanics(t *testing.T) {
+	c := testCompressor()
+	x := NewXOF(c, nil)
+	x.Write([]byte("abc"))
+	sumXOF(x, 8)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Write after Read did not panic")
+		}
+	}()
+	x.Write([]byte("more"))
+}